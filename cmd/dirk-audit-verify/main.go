@@ -0,0 +1,41 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// dirk-audit-verify walks a dirk audit log's rolling hash chain and reports whether it is intact.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/attestantio/dirk/services/auditor"
+)
+
+func main() {
+	path := flag.String("log", "", "path to the audit log to verify")
+	flag.Parse()
+
+	if *path == "" {
+		fmt.Fprintln(os.Stderr, "usage: dirk-audit-verify -log <path>")
+		os.Exit(2)
+	}
+
+	count, err := auditor.VerifyFile(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "chain broken after %d valid record(s): %v\n", count, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("OK: %d record(s) verified\n", count)
+}
@@ -46,6 +46,34 @@ type Checkpoint struct {
 	Root  []byte
 }
 
+// MaxCommitteesPerSlot is the Electra MAX_COMMITTEES_PER_SLOT constant, used to size CommitteeBits in
+// SignBeaconAttestationV2Data.
+const MaxCommitteesPerSlot = 64
+
+// SignBeaconAttestationV2Data is passed to 'OnSignBeaconAttestationV2' rules.  It describes a post-Electra
+// (EIP-7549) attestation: a single aggregate can now cover multiple committees, so CommitteeBits is a
+// MAX_COMMITTEES_PER_SLOT-length bitvector identifying which committees the aggregate covers, and
+// AggregationBits indexes validators within those committees.  The on-chain Data.Index is always zero.
+type SignBeaconAttestationV2Data struct {
+	Domain          []byte
+	Slot            uint64
+	AggregationBits []byte
+	CommitteeBits   []byte
+	BeaconBlockRoot []byte
+	Source          *Checkpoint
+	Target          *Checkpoint
+}
+
+// SignBeaconAttestationsData is passed to 'OnSignBeaconAttestations' rules.  It carries a batch of
+// attestations, together with the account name and public key for each, so that they can be considered for
+// approval in a single call; this allows a backing store such as a slashing protection database to amortize
+// its round-trip across the whole batch rather than paying it once per attestation.
+type SignBeaconAttestationsData struct {
+	Accounts []string
+	PubKeys  [][]byte
+	Data     []*SignBeaconAttestationData
+}
+
 // SignBeaconProposalData is passed to 'OnSignBeaconProposal' rules.
 type SignBeaconProposalData struct {
 	Domain        []byte
@@ -56,6 +84,34 @@ type SignBeaconProposalData struct {
 	BodyRoot      []byte
 }
 
+// SignSyncCommitteeMessageData is passed to 'OnSignSyncCommitteeMessage' rules.
+type SignSyncCommitteeMessageData struct {
+	Domain          []byte
+	Slot            uint64
+	BeaconBlockRoot []byte
+}
+
+// SignSyncCommitteeSelectionData is passed to 'OnSignSyncCommitteeSelection' rules.
+type SignSyncCommitteeSelectionData struct {
+	Domain            []byte
+	Slot              uint64
+	SubcommitteeIndex uint64
+}
+
+// SignContributionAndProofData is passed to 'OnSignContributionAndProof' rules.  It covers the
+// SyncAggregatorSelectionProof-gated ContributionAndProof container: an aggregator index, the selection proof
+// that establishes the signer as the aggregator for the subcommittee, and the contribution being aggregated.
+type SignContributionAndProofData struct {
+	Domain                        []byte
+	AggregatorIndex               uint64
+	SelectionProof                []byte
+	ContributionSlot              uint64
+	ContributionBeaconBlockRoot   []byte
+	ContributionSubcommitteeIndex uint64
+	ContributionAggregationBits   []byte
+	ContributionSignature         []byte
+}
+
 // AccessAccountData is passed to 'OnAccessAccount' rules.
 type AccessAccountData struct {
 	Paths []string
@@ -91,8 +147,27 @@ type Service interface {
 	OnSign(ctx context.Context, metadata *ReqMetadata, req *SignData) Result
 	// OnSignBeaconAttestation is called when a request to sign a beacon block attestation needs to be approved.
 	OnSignBeaconAttestation(ctx context.Context, metadata *ReqMetadata, req *SignBeaconAttestationData) Result
+	// OnSignBeaconAttestations is called when a batch of beacon block attestations need to be approved.  The
+	// rules for the whole batch are run as a single invocation, but each attestation is approved or denied
+	// independently; the returned slice has one result per attestation, in the same order as the request.
+	OnSignBeaconAttestations(ctx context.Context, metadata []*ReqMetadata, req *SignBeaconAttestationsData) []Result
+	// OnSignBeaconAttestationV2 is called when a request to sign a post-Electra (EIP-7549) beacon block
+	// attestation needs to be approved.  Implementations must expand req.CommitteeBits into the set of
+	// committees it covers and evaluate surround/double-vote conditions as if it were one logical attestation
+	// per covered committee.
+	OnSignBeaconAttestationV2(ctx context.Context, metadata *ReqMetadata, req *SignBeaconAttestationV2Data) Result
 	// OnSignBeaconProposal is called when a request to sign a beacon block proposal needs to be approved.
 	OnSignBeaconProposal(ctx context.Context, metadata *ReqMetadata, req *SignBeaconProposalData) Result
+	// OnSignSyncCommitteeMessage is called when a request to sign an Altair sync-committee message needs to be
+	// approved.  Implementations should at minimum de-duplicate per (validator, slot, root) so that a
+	// compromised client cannot obtain signatures over conflicting sync-committee messages for the same slot.
+	OnSignSyncCommitteeMessage(ctx context.Context, metadata *ReqMetadata, req *SignSyncCommitteeMessageData) Result
+	// OnSignSyncCommitteeSelection is called when a request to sign a sync-committee aggregator selection proof
+	// needs to be approved.
+	OnSignSyncCommitteeSelection(ctx context.Context, metadata *ReqMetadata, req *SignSyncCommitteeSelectionData) Result
+	// OnSignContributionAndProof is called when a request to sign a sync-committee ContributionAndProof needs
+	// to be approved.
+	OnSignContributionAndProof(ctx context.Context, metadata *ReqMetadata, req *SignContributionAndProofData) Result
 	// OnLockWallet is called when a request to lock a wallet needs to be approved.
 	OnLockWallet(ctx context.Context, metadata *ReqMetadata, req *LockWalletData) Result
 	// OnUnlockWallet is called when a request to unlock a wallet needs to be approved.
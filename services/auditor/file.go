@@ -0,0 +1,88 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditor
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// FileBackend is an append-only, rolling-hash-chained local file audit sink.  Each line is a JSON-encoded
+// chainedRecord whose Hash embeds SHA-256(PrevHash || Record); deleting, reordering or editing a line that has
+// a later line after it breaks the chain from that point on, which VerifyFile detects.  Removing lines from
+// the end of the file leaves a shorter chain that still verifies.
+type FileBackend struct {
+	mu       sync.Mutex
+	file     *os.File
+	lastHash []byte
+}
+
+// NewFileBackend opens (or creates) the audit log at path, replaying any existing entries to recover the
+// current chain head so that new records continue the same chain across restarts.
+func NewFileBackend(path string) (*FileBackend, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open audit log")
+	}
+
+	lastHash := make([]byte, sha256.Size)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry chainedRecord
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Wrap(err, "failed to parse existing audit log")
+		}
+		lastHash = entry.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read existing audit log")
+	}
+
+	return &FileBackend{file: file, lastHash: lastHash}, nil
+}
+
+// Log appends record to the chain.
+func (b *FileBackend) Log(_ context.Context, record *Record) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hash, err := chainHash(b.lastHash, record)
+	if err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(&chainedRecord{Record: record, PrevHash: b.lastHash, Hash: hash})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit record")
+	}
+	line = append(line, '\n')
+	if _, err := b.file.Write(line); err != nil {
+		return errors.Wrap(err, "failed to write audit record")
+	}
+
+	b.lastHash = hash
+
+	return nil
+}
+
+// Close closes the underlying file.
+func (b *FileBackend) Close() error {
+	return b.file.Close()
+}
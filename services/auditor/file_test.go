@@ -0,0 +1,132 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditor_test
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/dirk/services/auditor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBackendVerifyRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	backend, err := auditor.NewFileBackend(path)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		record := &auditor.Record{
+			Timestamp:   time.Now(),
+			RequestID:   "req",
+			Client:      "client",
+			Account:     "wallet/account",
+			Action:      "SignBeaconAttestation",
+			RulesResult: "approved",
+			FinalResult: "succeeded",
+		}
+		require.NoError(t, backend.Log(context.Background(), record))
+	}
+	require.NoError(t, backend.Close())
+
+	count, err := auditor.VerifyFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+}
+
+func TestFileBackendResumesChainAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	backend, err := auditor.NewFileBackend(path)
+	require.NoError(t, err)
+	require.NoError(t, backend.Log(context.Background(), &auditor.Record{Action: "SignBeaconAttestation"}))
+	require.NoError(t, backend.Close())
+
+	// Reopen the same log; new entries must continue the existing chain rather than starting a fresh one.
+	backend, err = auditor.NewFileBackend(path)
+	require.NoError(t, err)
+	require.NoError(t, backend.Log(context.Background(), &auditor.Record{Action: "SignBeaconProposal"}))
+	require.NoError(t, backend.Close())
+
+	count, err := auditor.VerifyFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
+
+func TestVerifyFileDetectsTamperedRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	backend, err := auditor.NewFileBackend(path)
+	require.NoError(t, err)
+	require.NoError(t, backend.Log(context.Background(), &auditor.Record{Action: "SignBeaconAttestation"}))
+	require.NoError(t, backend.Log(context.Background(), &auditor.Record{Action: "SignBeaconProposal"}))
+	require.NoError(t, backend.Close())
+
+	lines := readLines(t, path)
+	require.Len(t, lines, 2)
+	lines[0] = strings.Replace(lines[0], "SignBeaconAttestation", "SignBeaconProposal", 1)
+	writeLines(t, path, lines)
+
+	count, err := auditor.VerifyFile(path)
+	require.Error(t, err)
+	require.Equal(t, 0, count)
+}
+
+func TestVerifyFileDetectsDeletedRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	backend, err := auditor.NewFileBackend(path)
+	require.NoError(t, err)
+	require.NoError(t, backend.Log(context.Background(), &auditor.Record{Action: "SignBeaconAttestation"}))
+	require.NoError(t, backend.Log(context.Background(), &auditor.Record{Action: "SignBeaconProposal"}))
+	require.NoError(t, backend.Log(context.Background(), &auditor.Record{Action: "SignContributionAndProof"}))
+	require.NoError(t, backend.Close())
+
+	lines := readLines(t, path)
+	require.Len(t, lines, 3)
+	writeLines(t, path, []string{lines[0], lines[2]})
+
+	count, err := auditor.VerifyFile(path)
+	require.Error(t, err)
+	require.Equal(t, 1, count)
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+
+	return lines
+}
+
+func writeLines(t *testing.T, path string, lines []string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+}
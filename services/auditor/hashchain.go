@@ -0,0 +1,45 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditor
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// chainedRecord is the on-disk representation of a Record written by FileBackend: the record itself, the
+// hash of the previous entry, and this entry's own hash over (PrevHash || Record).  A verifier that
+// recomputes Hash from Record and PrevHash for every line can detect any edit or reordering, and any deletion
+// except of trailing entries.
+type chainedRecord struct {
+	Record   *Record `json:"record"`
+	PrevHash []byte  `json:"prev_hash"`
+	Hash     []byte  `json:"hash"`
+}
+
+// chainHash computes SHA-256(prevHash || record) for the rolling hash chain.
+func chainHash(prevHash []byte, record *Record) ([]byte, error) {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal audit record")
+	}
+
+	h := sha256.New()
+	h.Write(prevHash)
+	h.Write(recordBytes)
+
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,35 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditor
+
+import "context"
+
+// MultiBackend fans a record out to multiple backends, for example a hash-chained local file plus a network
+// sink for off-box retention.  Every backend is always attempted; the first error, if any, is returned once
+// all have been tried.
+type MultiBackend struct {
+	Backends []Service
+}
+
+// Log writes record to every configured backend.
+func (m *MultiBackend) Log(ctx context.Context, record *Record) error {
+	var firstErr error
+	for _, backend := range m.Backends {
+		if err := backend.Log(ctx, record); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,99 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditor
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultWriteTimeout bounds how long a single Log call may block on a slow or stalled collector, so that a
+// collector-side stall can never block the signing path indefinitely.
+const defaultWriteTimeout = 5 * time.Second
+
+// NetworkBackend streams JSON-encoded records to a remote collector over TCP, one object per line, so that
+// records can be forwarded to a syslog relay or log-aggregation pipeline.  It does not maintain a hash chain
+// of its own; pair it with FileBackend behind a MultiBackend where tamper-evidence is required as well as
+// off-box delivery.
+type NetworkBackend struct {
+	mu           sync.Mutex
+	address      string
+	dialer       net.Dialer
+	writeTimeout time.Duration
+	conn         net.Conn
+}
+
+// NewNetworkBackend returns a backend that lazily dials addr (host:port) and writes one JSON record per
+// line, reconnecting on the next Log call if the connection has dropped.
+func NewNetworkBackend(addr string) *NetworkBackend {
+	return &NetworkBackend{
+		address:      addr,
+		dialer:       net.Dialer{Timeout: 5 * time.Second},
+		writeTimeout: defaultWriteTimeout,
+	}
+}
+
+// Log writes record to the remote collector, reconnecting first if necessary.  The write is bounded by
+// writeTimeout so a slow or stalled collector cannot block the signing path that calls this synchronously.
+func (b *NetworkBackend) Log(ctx context.Context, record *Record) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.conn == nil {
+		conn, err := b.dialer.DialContext(ctx, "tcp", b.address)
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to audit log collector")
+		}
+		b.conn = conn
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit record")
+	}
+	line = append(line, '\n')
+
+	if err := b.conn.SetWriteDeadline(time.Now().Add(b.writeTimeout)); err != nil {
+		_ = b.conn.Close()
+		b.conn = nil
+		return errors.Wrap(err, "failed to set write deadline")
+	}
+
+	if _, err := b.conn.Write(line); err != nil {
+		_ = b.conn.Close()
+		b.conn = nil
+		return errors.Wrap(err, "failed to write audit record")
+	}
+
+	return nil
+}
+
+// Close closes the underlying connection, if one is open.
+func (b *NetworkBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.conn == nil {
+		return nil
+	}
+	err := b.conn.Close()
+	b.conn = nil
+
+	return err
+}
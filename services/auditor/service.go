@@ -0,0 +1,48 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditor provides a structured, tamper-evident audit trail of every signing decision dirk makes.
+// Unlike the ad-hoc zerolog lines emitted elsewhere, records written here are intended for compliance
+// retention: each one is self-describing and, for the local file backend, chained to its predecessor so that
+// deletion or reordering of entries can be detected after the fact.
+package auditor
+
+import (
+	"context"
+	"time"
+)
+
+// Record is a single structured, tamper-evident record of a signing decision.  One is produced for every
+// signing request the standard signer puts to the ruler, whether it was approved, denied or failed; requests
+// rejected before that point (missing credentials, malformed data, failed account checks) are not recorded.
+type Record struct {
+	Timestamp   time.Time
+	RequestID   string
+	Client      string
+	Account     string
+	PubKey      []byte
+	Action      string
+	Domain      []byte
+	DataHash    []byte
+	RulesResult string
+	FinalResult string
+	SigningRoot []byte
+}
+
+// Service is implemented by an audit sink.  The standard signer calls Log for every sign path, after the
+// ruler has reached a verdict and before the result is returned to the caller.
+type Service interface {
+	// Log writes a single audit record.  Implementations must not block signing indefinitely; a backend that
+	// cannot accept the record promptly should return an error rather than stall the caller.
+	Log(ctx context.Context, record *Record) error
+}
@@ -0,0 +1,68 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditor
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// VerifyFile walks the rolling hash chain in the audit log at path, recomputing each entry's hash from its
+// record and the previous entry's hash.  It returns the number of records that verified successfully; if the
+// chain is broken it also returns an error identifying the first bad record, which is everything an operator
+// needs to know a deletion, reordering or edit has occurred and where.  Records removed from the end of the
+// log leave no break in the chain and are reported only as a lower count.
+func VerifyFile(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to open audit log")
+	}
+	defer file.Close()
+
+	lastHash := make([]byte, sha256.Size)
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		count++
+
+		var entry chainedRecord
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return count - 1, errors.Wrapf(err, "malformed entry at record %d", count)
+		}
+		if !bytes.Equal(entry.PrevHash, lastHash) {
+			return count - 1, fmt.Errorf("record %d: previous-hash does not match preceding record; chain broken", count)
+		}
+
+		expectedHash, err := chainHash(lastHash, entry.Record)
+		if err != nil {
+			return count - 1, err
+		}
+		if !bytes.Equal(entry.Hash, expectedHash) {
+			return count - 1, fmt.Errorf("record %d: hash does not match its content; record has been tampered with", count)
+		}
+
+		lastHash = entry.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return count, errors.Wrap(err, "failed to read audit log")
+	}
+
+	return count, nil
+}
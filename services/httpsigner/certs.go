@@ -0,0 +1,30 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsigner
+
+import (
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+)
+
+// clientCAPool builds a certificate pool from a PEM-encoded CA certificate, used to validate client
+// certificates presented to the HTTP signing server.
+func clientCAPool(caCert []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, errors.New("failed to parse client CA certificate")
+	}
+	return pool, nil
+}
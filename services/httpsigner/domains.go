@@ -0,0 +1,46 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsigner
+
+import "crypto/sha256"
+
+// Domain types, as defined by the Ethereum 2 specification.  Web3Signer requests carry fork information
+// rather than a precomputed domain, so dirk has to derive it itself.
+var (
+	domainBeaconAttester              = [4]byte{0x01, 0x00, 0x00, 0x00}
+	domainBeaconProposer              = [4]byte{0x00, 0x00, 0x00, 0x00}
+	domainSyncCommittee               = [4]byte{0x07, 0x00, 0x00, 0x00}
+	domainSyncCommitteeSelectionProof = [4]byte{0x08, 0x00, 0x00, 0x00}
+	domainContributionAndProof        = [4]byte{0x09, 0x00, 0x00, 0x00}
+)
+
+// computeDomain derives a signing domain from a domain type, fork version and genesis validators root, per
+// compute_domain() in the Ethereum 2 specification.  Because ForkData has only two fixed-size fields its
+// hash-tree-root reduces to a single sha256 of the two 32-byte chunks, so no SSZ library is required here.
+func computeDomain(domainType [4]byte, currentVersion [4]byte, genesisValidatorsRoot [32]byte) [32]byte {
+	var versionChunk [32]byte
+	copy(versionChunk[:], currentVersion[:])
+
+	h := sha256.New()
+	h.Write(versionChunk[:])
+	h.Write(genesisValidatorsRoot[:])
+	var forkDataRoot [32]byte
+	copy(forkDataRoot[:], h.Sum(nil))
+
+	var domain [32]byte
+	copy(domain[:4], domainType[:])
+	copy(domain[4:], forkDataRoot[:28])
+
+	return domain
+}
@@ -0,0 +1,48 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsigner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDomain(t *testing.T) {
+	var genesisValidatorsRoot [32]byte
+	copy(genesisValidatorsRoot[:], []byte("some deterministic genesis root"))
+	currentVersion := [4]byte{0x01, 0x02, 0x03, 0x04}
+
+	domain := computeDomain(domainBeaconAttester, currentVersion, genesisValidatorsRoot)
+
+	// The first four bytes are always the domain type verbatim.
+	require.Equal(t, domainBeaconAttester[:], domain[:4])
+
+	// Same inputs must always produce the same domain.
+	require.Equal(t, domain, computeDomain(domainBeaconAttester, currentVersion, genesisValidatorsRoot))
+
+	// Changing the domain type, fork version or genesis validators root must each change the result; the
+	// attester domain type must differ from each of dirk's other domain types.
+	require.NotEqual(t, domain, computeDomain(domainBeaconProposer, currentVersion, genesisValidatorsRoot))
+	require.NotEqual(t, domain, computeDomain(domainSyncCommittee, currentVersion, genesisValidatorsRoot))
+	require.NotEqual(t, domain, computeDomain(domainSyncCommitteeSelectionProof, currentVersion, genesisValidatorsRoot))
+	require.NotEqual(t, domain, computeDomain(domainContributionAndProof, currentVersion, genesisValidatorsRoot))
+
+	otherVersion := [4]byte{0x05, 0x06, 0x07, 0x08}
+	require.NotEqual(t, domain, computeDomain(domainBeaconAttester, otherVersion, genesisValidatorsRoot))
+
+	var otherRoot [32]byte
+	copy(otherRoot[:], []byte("a different genesis validators root"))
+	require.NotEqual(t, domain, computeDomain(domainBeaconAttester, currentVersion, otherRoot))
+}
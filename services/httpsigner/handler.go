@@ -0,0 +1,380 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsigner
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/dirk/core"
+	"github.com/attestantio/dirk/rules"
+	"github.com/attestantio/dirk/services/checker"
+	"github.com/google/uuid"
+)
+
+// handleSign implements `POST /api/v1/eth2/sign/{identifier}`.  It decodes the Web3Signer request body into
+// the matching rules.Sign…Data struct, runs it through the same signer used by the gRPC service, and returns
+// the resulting BLS signature as hex.
+func (s *Service) handleSign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	identifier := strings.TrimPrefix(r.URL.Path, "/api/v1/eth2/sign/")
+	if identifier == "" {
+		writeError(w, http.StatusBadRequest, "missing identifier")
+		return
+	}
+	pubKey, err := parseHex(identifier)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid identifier")
+		return
+	}
+
+	var req signRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	credentials := &checker.Credentials{
+		RequestID: uuid.New().String(),
+		Client:    clientName(r),
+		IP:        r.RemoteAddr,
+	}
+
+	var (
+		result     core.Result
+		signature  []byte
+		handlerErr error
+	)
+
+	switch req.Type {
+	case "ATTESTATION":
+		signature, result, handlerErr = s.signAttestation(r, credentials, pubKey, req)
+	case "BLOCK_V2":
+		signature, result, handlerErr = s.signBlockV2(r, credentials, pubKey, req)
+	case "SYNC_COMMITTEE_MESSAGE":
+		signature, result, handlerErr = s.signSyncCommitteeMessage(r, credentials, pubKey, req)
+	case "CONTRIBUTION_AND_PROOF":
+		signature, result, handlerErr = s.signContributionAndProof(r, credentials, pubKey, req)
+	case "SYNC_COMMITTEE_SELECTION_PROOF":
+		signature, result, handlerErr = s.signSyncCommitteeSelection(r, credentials, pubKey, req)
+	case "SIGN":
+		signature, result, handlerErr = s.signGeneric(r, credentials, pubKey, req)
+	case "AGGREGATE_AND_PROOF", "VOLUNTARY_EXIT", "RANDAO_REVEAL", "DEPOSIT":
+		writeError(w, http.StatusNotImplemented, fmt.Sprintf("request type %q is not yet supported", req.Type))
+		return
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown request type %q", req.Type))
+		return
+	}
+
+	if handlerErr != nil {
+		writeError(w, http.StatusBadRequest, handlerErr.Error())
+		return
+	}
+
+	switch result {
+	case core.ResultSucceeded:
+		writeJSON(w, http.StatusOK, signResponse{Signature: "0x" + hex.EncodeToString(signature)})
+	case core.ResultDenied:
+		writeError(w, http.StatusForbidden, "denied")
+	default:
+		writeError(w, http.StatusInternalServerError, "signing failed")
+	}
+}
+
+func (s *Service) signAttestation(r *http.Request, credentials *checker.Credentials, pubKey []byte, req signRequest) ([]byte, core.Result, error) {
+	if req.Attestation == nil {
+		return nil, core.ResultFailed, errMissingField("attestation")
+	}
+	domain, err := req.domain(domainBeaconAttester)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	data, err := attestationData(req.Attestation, domain)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	result, signature := s.signer.SignBeaconAttestation(r.Context(), credentials, "", pubKey, data)
+	return signature, result, nil
+}
+
+func (s *Service) signBlockV2(r *http.Request, credentials *checker.Credentials, pubKey []byte, req signRequest) ([]byte, core.Result, error) {
+	if req.BeaconBlock == nil || req.BeaconBlock.Block == nil {
+		return nil, core.ResultFailed, errMissingField("beacon_block")
+	}
+	domain, err := req.domain(domainBeaconProposer)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	header := req.BeaconBlock.Block
+	slot, err := parseUint64(header.Slot)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	proposerIndex, err := parseUint64(header.ProposerIndex)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	parentRoot, err := parseHex(header.ParentRoot)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	stateRoot, err := parseHex(header.StateRoot)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	bodyRoot, err := parseHex(header.BodyRoot)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	data := &rules.SignBeaconProposalData{
+		Domain:        domain,
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		ParentRoot:    parentRoot,
+		StateRoot:     stateRoot,
+		BodyRoot:      bodyRoot,
+	}
+	result, signature := s.signer.SignBeaconProposal(r.Context(), credentials, "", pubKey, data)
+	return signature, result, nil
+}
+
+func (s *Service) signSyncCommitteeMessage(r *http.Request, credentials *checker.Credentials, pubKey []byte, req signRequest) ([]byte, core.Result, error) {
+	if req.SyncCommitteeMessage == nil {
+		return nil, core.ResultFailed, errMissingField("sync_committee_message")
+	}
+	domain, err := req.domain(domainSyncCommittee)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	slot, err := parseUint64(req.SyncCommitteeMessage.Slot)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	beaconBlockRoot, err := parseHex(req.SyncCommitteeMessage.BeaconBlockRoot)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	data := &rules.SignSyncCommitteeMessageData{
+		Domain:          domain,
+		Slot:            slot,
+		BeaconBlockRoot: beaconBlockRoot,
+	}
+	result, signature := s.signer.SignSyncCommitteeMessage(r.Context(), credentials, "", pubKey, data)
+	return signature, result, nil
+}
+
+func (s *Service) signContributionAndProof(r *http.Request, credentials *checker.Credentials, pubKey []byte, req signRequest) ([]byte, core.Result, error) {
+	if req.ContributionAndProof == nil || req.ContributionAndProof.Contribution == nil {
+		return nil, core.ResultFailed, errMissingField("contribution_and_proof")
+	}
+	domain, err := req.domain(domainContributionAndProof)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	contributionAndProof := req.ContributionAndProof
+	contribution := contributionAndProof.Contribution
+
+	aggregatorIndex, err := parseUint64(contributionAndProof.AggregatorIndex)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	selectionProof, err := parseHex(contributionAndProof.SelectionProof)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	contributionSlot, err := parseUint64(contribution.Slot)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	contributionBeaconBlockRoot, err := parseHex(contribution.BeaconBlockRoot)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	contributionSubcommitteeIndex, err := parseUint64(contribution.SubcommitteeIndex)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	contributionAggregationBits, err := parseHex(contribution.AggregationBits)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	contributionSignature, err := parseHex(contribution.Signature)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+
+	data := &rules.SignContributionAndProofData{
+		Domain:                        domain,
+		AggregatorIndex:               aggregatorIndex,
+		SelectionProof:                selectionProof,
+		ContributionSlot:              contributionSlot,
+		ContributionBeaconBlockRoot:   contributionBeaconBlockRoot,
+		ContributionSubcommitteeIndex: contributionSubcommitteeIndex,
+		ContributionAggregationBits:   contributionAggregationBits,
+		ContributionSignature:         contributionSignature,
+	}
+	result, signature := s.signer.SignContributionAndProof(r.Context(), credentials, "", pubKey, data)
+	return signature, result, nil
+}
+
+func (s *Service) signSyncCommitteeSelection(r *http.Request, credentials *checker.Credentials, pubKey []byte, req signRequest) ([]byte, core.Result, error) {
+	if req.SyncAggregatorSelectionData == nil {
+		return nil, core.ResultFailed, errMissingField("sync_aggregator_selection_data")
+	}
+	domain, err := req.domain(domainSyncCommitteeSelectionProof)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	slot, err := parseUint64(req.SyncAggregatorSelectionData.Slot)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	subcommitteeIndex, err := parseUint64(req.SyncAggregatorSelectionData.SubcommitteeIndex)
+	if err != nil {
+		return nil, core.ResultFailed, err
+	}
+	data := &rules.SignSyncCommitteeSelectionData{
+		Domain:            domain,
+		Slot:              slot,
+		SubcommitteeIndex: subcommitteeIndex,
+	}
+	result, signature := s.signer.SignSyncCommitteeSelection(r.Context(), credentials, "", pubKey, data)
+	return signature, result, nil
+}
+
+// signGeneric handles the "SIGN" request type, dirk's own extension to the Web3Signer protocol for signing
+// arbitrary pre-hashed data that has no dedicated eth2 message type.  Unlike every other type it carries its
+// domain and data directly rather than deriving them from fork_info.
+func (s *Service) signGeneric(r *http.Request, credentials *checker.Credentials, pubKey []byte, req signRequest) ([]byte, core.Result, error) {
+	domain, err := parseHex(req.Domain)
+	if err != nil {
+		return nil, core.ResultFailed, errMissingField("domain")
+	}
+	signingData, err := parseHex(req.SigningData)
+	if err != nil {
+		return nil, core.ResultFailed, errMissingField("signing_data")
+	}
+	data := &rules.SignData{
+		Domain: domain,
+		Data:   signingData,
+	}
+	result, signature := s.signer.Sign(r.Context(), credentials, "", pubKey, data)
+	return signature, result, nil
+}
+
+// domain derives the signing domain for this request from its fork_info, which Web3Signer always supplies in
+// place of a precomputed domain.
+func (r *signRequest) domain(domainType [4]byte) ([]byte, error) {
+	if r.ForkInfo == nil || r.ForkInfo.Fork == nil {
+		return nil, errMissingField("fork_info")
+	}
+	currentVersion, err := parseHex(r.ForkInfo.Fork.CurrentVersion)
+	if err != nil || len(currentVersion) != 4 {
+		return nil, errMissingField("fork_info.fork.current_version")
+	}
+	genesisValidatorsRoot, err := parseHex(r.ForkInfo.GenesisValidatorsRoot)
+	if err != nil || len(genesisValidatorsRoot) != 32 {
+		return nil, errMissingField("fork_info.genesis_validators_root")
+	}
+	var version [4]byte
+	copy(version[:], currentVersion)
+	var root [32]byte
+	copy(root[:], genesisValidatorsRoot)
+	domain := computeDomain(domainType, version, root)
+	return domain[:], nil
+}
+
+func attestationData(a *web3SignerAttestation, domain []byte) (*rules.SignBeaconAttestationData, error) {
+	slot, err := parseUint64(a.Slot)
+	if err != nil {
+		return nil, err
+	}
+	committeeIndex, err := parseUint64(a.Index)
+	if err != nil {
+		return nil, err
+	}
+	beaconBlockRoot, err := parseHex(a.BeaconBlockRoot)
+	if err != nil {
+		return nil, err
+	}
+	source, err := checkpointData(a.Source)
+	if err != nil {
+		return nil, err
+	}
+	target, err := checkpointData(a.Target)
+	if err != nil {
+		return nil, err
+	}
+	return &rules.SignBeaconAttestationData{
+		Domain:          domain,
+		Slot:            slot,
+		CommitteeIndex:  committeeIndex,
+		BeaconBlockRoot: beaconBlockRoot,
+		Source:          source,
+		Target:          target,
+	}, nil
+}
+
+func checkpointData(c *checkpoint) (*rules.Checkpoint, error) {
+	if c == nil {
+		return nil, errMissingField("checkpoint")
+	}
+	epoch, err := parseUint64(c.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseHex(c.Root)
+	if err != nil {
+		return nil, err
+	}
+	return &rules.Checkpoint{Epoch: epoch, Root: root}, nil
+}
+
+func parseHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+func parseUint64(s string) (uint64, error) {
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func errMissingField(name string) error {
+	return fmt.Errorf("missing or invalid field %q", name)
+}
+
+func clientName(r *http.Request) string {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates[0].Subject.CommonName
+	}
+	return "unknown"
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
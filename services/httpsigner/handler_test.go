@@ -0,0 +1,59 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsigner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationData(t *testing.T) {
+	domain := []byte("0123456789012345678901234567890")
+
+	req := &web3SignerAttestation{
+		Slot:            "123",
+		Index:           "4",
+		BeaconBlockRoot: "0x0100000000000000000000000000000000000000000000000000000000000000",
+		Source: &checkpoint{
+			Epoch: "1",
+			Root:  "0x0200000000000000000000000000000000000000000000000000000000000000",
+		},
+		Target: &checkpoint{
+			Epoch: "2",
+			Root:  "0x0300000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+
+	data, err := attestationData(req, domain)
+	require.NoError(t, err)
+	require.Equal(t, uint64(123), data.Slot)
+	require.Equal(t, uint64(4), data.CommitteeIndex)
+	require.Equal(t, uint64(1), data.Source.Epoch)
+	require.Equal(t, uint64(2), data.Target.Epoch)
+	require.Equal(t, domain, data.Domain)
+}
+
+func TestAttestationDataInvalidIndex(t *testing.T) {
+	req := &web3SignerAttestation{
+		Slot:            "123",
+		Index:           "not-a-number",
+		BeaconBlockRoot: "0x0100000000000000000000000000000000000000000000000000000000000000",
+		Source:          &checkpoint{Epoch: "1", Root: "0x0200000000000000000000000000000000000000000000000000000000000000"},
+		Target:          &checkpoint{Epoch: "2", Root: "0x0300000000000000000000000000000000000000000000000000000000000000"},
+	}
+
+	_, err := attestationData(req, []byte("domain"))
+	require.Error(t, err)
+}
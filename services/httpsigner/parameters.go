@@ -0,0 +1,113 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsigner
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel      zerolog.Level
+	listenAddress string
+	serverCert    []byte
+	serverKey     []byte
+	caCert        []byte
+	signer        signerService
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithListenAddress sets the address on which the HTTP signing server listens.
+func WithListenAddress(listenAddress string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.listenAddress = listenAddress
+	})
+}
+
+// WithServerCert sets the PEM-encoded server certificate used for the HTTPS listener.
+func WithServerCert(cert []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.serverCert = cert
+	})
+}
+
+// WithServerKey sets the PEM-encoded server key used for the HTTPS listener.
+func WithServerKey(key []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.serverKey = key
+	})
+}
+
+// WithCACert sets the PEM-encoded client CA certificate used to authenticate callers, matching the CA used by
+// the gRPC server.
+func WithCACert(caCert []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.caCert = caCert
+	})
+}
+
+// WithSigner sets the signing service to which requests are dispatched.
+func WithSigner(signer signerService) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.signer = signer
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and
+// correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.listenAddress == "" {
+		return nil, errors.New("no listen address specified")
+	}
+	if parameters.serverCert == nil {
+		return nil, errors.New("no server certificate specified")
+	}
+	if parameters.serverKey == nil {
+		return nil, errors.New("no server key specified")
+	}
+	if parameters.caCert == nil {
+		return nil, errors.New("no client CA certificate specified")
+	}
+	if parameters.signer == nil {
+		return nil, errors.New("no signer specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,134 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpsigner exposes the signing operations of the gRPC standard.Service over an HTTP+JSON API that
+// is wire-compatible with Consensys Web3Signer's `/api/v1/eth2/sign/{identifier}` endpoint, so that validator
+// clients which only speak the Web3Signer protocol (Teku, Lighthouse, Nimbus) can use dirk directly.
+package httpsigner
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/attestantio/dirk/core"
+	"github.com/attestantio/dirk/rules"
+	"github.com/attestantio/dirk/services/checker"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// module-wide log.
+var log zerolog.Logger
+
+// signerService is the subset of the gRPC standard.Service signing operations this façade dispatches to.  It
+// is satisfied by *standard.Service; declaring it locally avoids an import cycle between the gRPC service and
+// this façade.
+type signerService interface {
+	Sign(ctx context.Context, credentials *checker.Credentials, accountName string, pubKey []byte, data *rules.SignData) (core.Result, []byte)
+	SignBeaconAttestation(ctx context.Context, credentials *checker.Credentials, accountName string, pubKey []byte, data *rules.SignBeaconAttestationData) (core.Result, []byte)
+	SignBeaconProposal(ctx context.Context, credentials *checker.Credentials, accountName string, pubKey []byte, data *rules.SignBeaconProposalData) (core.Result, []byte)
+	SignSyncCommitteeMessage(ctx context.Context, credentials *checker.Credentials, accountName string, pubKey []byte, data *rules.SignSyncCommitteeMessageData) (core.Result, []byte)
+	SignSyncCommitteeSelection(ctx context.Context, credentials *checker.Credentials, accountName string, pubKey []byte, data *rules.SignSyncCommitteeSelectionData) (core.Result, []byte)
+	SignContributionAndProof(ctx context.Context, credentials *checker.Credentials, accountName string, pubKey []byte, data *rules.SignContributionAndProofData) (core.Result, []byte)
+}
+
+// Service provides a Web3Signer-compatible HTTP+JSON façade over dirk's signing operations.
+type Service struct {
+	signer        signerService
+	listenAddress string
+	serverCert    []byte
+	serverKey     []byte
+	caCert        []byte
+	server        *http.Server
+}
+
+// New creates a new httpsigner service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	log = zerolog.New(zerolog.NewConsoleWriter()).With().Str("service", "httpsigner").Str("impl", "http").Logger().Level(parameters.logLevel)
+
+	s := &Service{
+		signer:        parameters.signer,
+		listenAddress: parameters.listenAddress,
+		serverCert:    parameters.serverCert,
+		serverKey:     parameters.serverKey,
+		caCert:        parameters.caCert,
+	}
+
+	if err := s.start(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to start HTTP signing server")
+	}
+
+	return s, nil
+}
+
+// start brings up the HTTPS listener.  TLS configuration mirrors the gRPC server: mutual TLS with the same
+// server certificate and client CA, so authorization decisions are driven by the same client certificates
+// used everywhere else in dirk.
+func (s *Service) start(ctx context.Context) error {
+	cert, err := tls.X509KeyPair(s.serverCert, s.serverKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to load server keypair")
+	}
+
+	certPool, err := clientCAPool(s.caCert)
+	if err != nil {
+		return errors.Wrap(err, "failed to build client CA pool")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/eth2/sign/", s.handleSign)
+	mux.HandleFunc("/upcheck", s.handleUpcheck)
+
+	s.server = &http.Server{
+		Addr:    s.listenAddress,
+		Handler: mux,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    certPool,
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+
+	listener, err := net.Listen("tcp", s.listenAddress)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen")
+	}
+
+	go func() {
+		if err := s.server.ServeTLS(listener, "", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("HTTP signing server stopped unexpectedly")
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		if err := s.server.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close HTTP signing server cleanly")
+		}
+	}()
+
+	return nil
+}
+
+func (s *Service) handleUpcheck(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
@@ -0,0 +1,103 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpsigner
+
+// signRequest is the envelope of a Web3Signer `/api/v1/eth2/sign/{identifier}` request.  Only the fields
+// dirk understands for each `type` are populated by the caller; the rest are left nil.  Numeric and byte
+// fields follow Web3Signer's convention of decimal strings and 0x-prefixed hex respectively.
+type signRequest struct {
+	Type     string    `json:"type"`
+	ForkInfo *forkInfo `json:"fork_info,omitempty"`
+
+	Attestation                 *web3SignerAttestation                 `json:"attestation,omitempty"`
+	Block                       *web3SignerBlockHeader                 `json:"block,omitempty"`
+	BeaconBlock                 *web3SignerBeaconBlock                 `json:"beacon_block,omitempty"`
+	SyncCommitteeMessage        *web3SignerSyncCommitteeMessage        `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *web3SignerSyncAggregatorSelectionData `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *web3SignerContributionAndProof        `json:"contribution_and_proof,omitempty"`
+
+	// Domain and SigningData are only used by the "SIGN" request type, a dirk-specific extension to the
+	// Web3Signer protocol for signing arbitrary pre-hashed data that has no dedicated eth2 message type.
+	Domain      string `json:"domain,omitempty"`
+	SigningData string `json:"signing_data,omitempty"`
+}
+
+type forkInfo struct {
+	Fork                  *fork  `json:"fork"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+}
+
+type fork struct {
+	CurrentVersion string `json:"current_version"`
+}
+
+type checkpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type web3SignerAttestation struct {
+	Slot            string      `json:"slot"`
+	Index           string      `json:"index"`
+	BeaconBlockRoot string      `json:"beacon_block_root"`
+	Source          *checkpoint `json:"source"`
+	Target          *checkpoint `json:"target"`
+}
+
+// web3SignerBeaconBlock wraps a BLOCK_V2 request, which carries a versioned block; dirk only needs the
+// header fields common to every fork for the purpose of computing the signing root.
+type web3SignerBeaconBlock struct {
+	Version string                 `json:"version"`
+	Block   *web3SignerBlockHeader `json:"block"`
+}
+
+type web3SignerBlockHeader struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+type web3SignerSyncCommitteeMessage struct {
+	BeaconBlockRoot string `json:"beacon_block_root"`
+	Slot            string `json:"slot"`
+}
+
+type web3SignerSyncAggregatorSelectionData struct {
+	Slot              string `json:"slot"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+}
+
+type web3SignerContributionAndProof struct {
+	AggregatorIndex string                               `json:"aggregator_index"`
+	Contribution    *web3SignerSyncCommitteeContribution `json:"contribution"`
+	SelectionProof  string                               `json:"selection_proof"`
+}
+
+type web3SignerSyncCommitteeContribution struct {
+	Slot              string `json:"slot"`
+	BeaconBlockRoot   string `json:"beacon_block_root"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+	AggregationBits   string `json:"aggregation_bits"`
+	Signature         string `json:"signature"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,95 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// defaultRequestTimeout is how long dirk waits for a single approval decision before denying by default.
+	defaultRequestTimeout = 2 * time.Second
+	// defaultHeartbeatInterval is how often dirk pings the approver process to detect a hang.
+	defaultHeartbeatInterval = 10 * time.Second
+)
+
+type parameters struct {
+	logLevel          zerolog.Level
+	command           []string
+	requestTimeout    time.Duration
+	heartbeatInterval time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithCommand sets the command (and arguments) used to spawn the external approver process.
+func WithCommand(command []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.command = command
+	})
+}
+
+// WithRequestTimeout sets how long dirk waits for a single approval decision before denying by default.
+func WithRequestTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.requestTimeout = timeout
+	})
+}
+
+// WithHeartbeatInterval sets how often dirk pings the approver process to detect a hang.
+func WithHeartbeatInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.heartbeatInterval = interval
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and
+// correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:          zerolog.GlobalLevel(),
+		requestTimeout:    defaultRequestTimeout,
+		heartbeatInterval: defaultHeartbeatInterval,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.command) == 0 {
+		return nil, errors.New("no approver command specified")
+	}
+
+	return &parameters, nil
+}
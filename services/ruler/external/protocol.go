@@ -0,0 +1,48 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import "encoding/json"
+
+// rpcRequest is a single JSON-RPC 2.0 call sent to the external approver process on its stdin, one per line.
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// rpcResponse is the approver's reply on its stdout, one per line.  Result is left raw because its shape
+// differs between single-result calls (a plain "approved"/"denied"/"failed" string) and the batch
+// OnSignBeaconAttestations call (an array of such strings).
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int64           `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is returned by an approver that wants to report a protocol-level failure rather than a rules
+// verdict; dirk treats it identically to rules.FAILED.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// approvalParams is the `params` payload for every OnSign…/OnAccess…/OnLock…/OnUnlock… call: the request
+// metadata dirk already carries internally, plus the request-specific data.
+type approvalParams struct {
+	Metadata interface{} `json:"metadata"`
+	Req      interface{} `json:"req"`
+}
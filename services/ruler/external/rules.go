@@ -0,0 +1,119 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/attestantio/dirk/rules"
+)
+
+// This file implements rules.Service by forwarding each method to the external approver as the JSON-RPC
+// method of the same name, e.g. OnSignBeaconAttestation sends {"method":"OnSignBeaconAttestation",...}.
+
+// OnListAccounts is called when a request to list accounts needs to be approved.
+func (s *Service) OnListAccounts(ctx context.Context, metadata *rules.ReqMetadata, req *rules.AccessAccountData) rules.Result {
+	return s.call(ctx, "OnListAccounts", metadata, req)
+}
+
+// OnSign is called when a request to sign generic data needs to be approved.
+func (s *Service) OnSign(ctx context.Context, metadata *rules.ReqMetadata, req *rules.SignData) rules.Result {
+	return s.call(ctx, "OnSign", metadata, req)
+}
+
+// OnSignBeaconAttestation is called when a request to sign a beacon block attestation needs to be approved.
+func (s *Service) OnSignBeaconAttestation(ctx context.Context, metadata *rules.ReqMetadata, req *rules.SignBeaconAttestationData) rules.Result {
+	return s.call(ctx, "OnSignBeaconAttestation", metadata, req)
+}
+
+// OnSignBeaconAttestations is called when a batch of beacon block attestations need to be approved.  Unlike
+// the other methods this expects the approver to reply with a JSON array of per-item results, in the same
+// order as the request, rather than a single result.
+func (s *Service) OnSignBeaconAttestations(ctx context.Context, metadata []*rules.ReqMetadata, req *rules.SignBeaconAttestationsData) []rules.Result {
+	results := make([]rules.Result, len(req.Data))
+
+	raw, err := s.invoke(ctx, "OnSignBeaconAttestations", &approvalParams{Metadata: metadata, Req: req})
+	if err != nil {
+		log.Error().Err(err).Msg("External approver batch call failed; denying")
+		for i := range results {
+			results[i] = rules.FAILED
+		}
+		return results
+	}
+
+	var rawResults []json.RawMessage
+	if err := json.Unmarshal(raw, &rawResults); err != nil || len(rawResults) != len(req.Data) {
+		log.Error().Err(err).Msg("External approver returned malformed batch result; denying")
+		for i := range results {
+			results[i] = rules.FAILED
+		}
+		return results
+	}
+
+	for i, r := range rawResults {
+		results[i] = decodeResult(r)
+	}
+
+	return results
+}
+
+// OnSignBeaconAttestationV2 is called when a request to sign a post-Electra (EIP-7549) beacon block
+// attestation needs to be approved.
+func (s *Service) OnSignBeaconAttestationV2(ctx context.Context, metadata *rules.ReqMetadata, req *rules.SignBeaconAttestationV2Data) rules.Result {
+	return s.call(ctx, "OnSignBeaconAttestationV2", metadata, req)
+}
+
+// OnSignBeaconProposal is called when a request to sign a beacon block proposal needs to be approved.
+func (s *Service) OnSignBeaconProposal(ctx context.Context, metadata *rules.ReqMetadata, req *rules.SignBeaconProposalData) rules.Result {
+	return s.call(ctx, "OnSignBeaconProposal", metadata, req)
+}
+
+// OnSignSyncCommitteeMessage is called when a request to sign an Altair sync-committee message needs to be
+// approved.
+func (s *Service) OnSignSyncCommitteeMessage(ctx context.Context, metadata *rules.ReqMetadata, req *rules.SignSyncCommitteeMessageData) rules.Result {
+	return s.call(ctx, "OnSignSyncCommitteeMessage", metadata, req)
+}
+
+// OnSignSyncCommitteeSelection is called when a request to sign a sync-committee aggregator selection proof
+// needs to be approved.
+func (s *Service) OnSignSyncCommitteeSelection(ctx context.Context, metadata *rules.ReqMetadata, req *rules.SignSyncCommitteeSelectionData) rules.Result {
+	return s.call(ctx, "OnSignSyncCommitteeSelection", metadata, req)
+}
+
+// OnSignContributionAndProof is called when a request to sign a sync-committee ContributionAndProof needs to
+// be approved.
+func (s *Service) OnSignContributionAndProof(ctx context.Context, metadata *rules.ReqMetadata, req *rules.SignContributionAndProofData) rules.Result {
+	return s.call(ctx, "OnSignContributionAndProof", metadata, req)
+}
+
+// OnLockWallet is called when a request to lock a wallet needs to be approved.
+func (s *Service) OnLockWallet(ctx context.Context, metadata *rules.ReqMetadata, req *rules.LockWalletData) rules.Result {
+	return s.call(ctx, "OnLockWallet", metadata, req)
+}
+
+// OnUnlockWallet is called when a request to unlock a wallet needs to be approved.
+func (s *Service) OnUnlockWallet(ctx context.Context, metadata *rules.ReqMetadata, req *rules.UnlockWalletData) rules.Result {
+	return s.call(ctx, "OnUnlockWallet", metadata, req)
+}
+
+// OnLockAccount is called when a request to lock an account needs to be approved.
+func (s *Service) OnLockAccount(ctx context.Context, metadata *rules.ReqMetadata, req *rules.LockAccountData) rules.Result {
+	return s.call(ctx, "OnLockAccount", metadata, req)
+}
+
+// OnUnlockAccount is called when a request to unlock an account needs to be approved.
+func (s *Service) OnUnlockAccount(ctx context.Context, metadata *rules.ReqMetadata, req *rules.UnlockAccountData) rules.Result {
+	return s.call(ctx, "OnUnlockAccount", metadata, req)
+}
@@ -0,0 +1,262 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external implements rules.Service by delegating every approval decision to a user-supplied
+// approver process, spoken to over a line-delimited JSON-RPC protocol on its stdin/stdout.  This lets
+// operators write approval policy in any language - Python, JS, a WASM sandbox - rather than only through
+// the compiled Go rules.Service interface, mirroring the external-signer/UI model used elsewhere in the
+// Ethereum signer ecosystem.
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/dirk/rules"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// module-wide log.
+var log zerolog.Logger
+
+// Service implements rules.Service by forwarding every call to an external approver process.
+type Service struct {
+	command           []string
+	requestTimeout    time.Duration
+	heartbeatInterval time.Duration
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	nextID  int64
+	pending map[int64]chan rpcResponse
+
+	healthy int32 // atomic bool; 0 = unhealthy (fail closed without waiting out the timeout)
+}
+
+// New creates a new external-approver rules service, spawning the configured command immediately so that a
+// misconfigured approver is caught at startup rather than on the first signing request.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	log = zerolog.New(zerolog.NewConsoleWriter()).With().Str("service", "ruler").Str("impl", "external").Logger().Level(parameters.logLevel)
+
+	s := &Service{
+		command:           parameters.command,
+		requestTimeout:    parameters.requestTimeout,
+		heartbeatInterval: parameters.heartbeatInterval,
+		pending:           make(map[int64]chan rpcResponse),
+	}
+
+	if err := s.spawn(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to start external approver")
+	}
+
+	go s.heartbeat(ctx)
+
+	return s, nil
+}
+
+// spawn starts the approver process and a goroutine that reads its responses from stdout.
+func (s *Service) spawn(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain approver stdin")
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain approver stdout")
+	}
+
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start approver process")
+	}
+
+	s.mu.Lock()
+	s.cmd = cmd
+	s.stdin = stdin
+	s.mu.Unlock()
+	atomic.StoreInt32(&s.healthy, 1)
+
+	go func() {
+		s.readLoop(stdout)
+		// The approver has stopped writing to stdout; reap its process now rather than leaving a zombie
+		// around until the whole dirk process exits.
+		if err := cmd.Wait(); err != nil {
+			log.Warn().Err(err).Msg("External approver process exited")
+		}
+	}()
+
+	return nil
+}
+
+// readLoop dispatches each line the approver writes to whichever invoke call is waiting on its ID.
+func (s *Service) readLoop(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var resp rpcResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			log.Warn().Err(err).Msg("Received malformed response from external approver")
+			continue
+		}
+
+		s.mu.Lock()
+		ch, ok := s.pending[resp.ID]
+		if ok {
+			delete(s.pending, resp.ID)
+		}
+		s.mu.Unlock()
+
+		if ok {
+			ch <- resp
+		}
+	}
+
+	// The approver's stdout has closed and the process is gone; this package has no logic to respawn it, so
+	// every decision from here denies fail-closed until the whole dirk process is restarted.
+	atomic.StoreInt32(&s.healthy, 0)
+	log.Warn().Msg("External approver connection closed")
+}
+
+// heartbeat periodically pings the approver so that a hung process is detected even when no signing
+// requests are arriving, rather than only failing the next real request after a full timeout.
+func (s *Service) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(s.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.invoke(ctx, "ping", nil); err != nil {
+				atomic.StoreInt32(&s.healthy, 0)
+				log.Warn().Err(err).Msg("External approver failed heartbeat; denying by default until it responds")
+			} else {
+				atomic.StoreInt32(&s.healthy, 1)
+			}
+		}
+	}
+}
+
+// invoke sends method/params to the approver and waits for its matching response, denying by timeout.  A
+// hung or dead approver can never stall a caller past requestTimeout.
+func (s *Service) invoke(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	if atomic.LoadInt32(&s.healthy) == 0 && method != "ping" {
+		return nil, errors.New("external approver is not healthy")
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	respCh := make(chan rpcResponse, 1)
+	s.pending[id] = respCh
+	stdin := s.stdin
+	s.mu.Unlock()
+
+	line, err := json.Marshal(&rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+	line = append(line, '\n')
+
+	// The write runs on its own goroutine so that an approver which has stopped reading its stdin cannot hold
+	// this call past requestTimeout.
+	writeErr := make(chan error, 1)
+	go func() {
+		if _, err := stdin.Write(line); err != nil {
+			writeErr <- err
+		}
+	}()
+
+	timer := time.NewTimer(s.requestTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-writeErr:
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+		return nil, errors.Wrap(err, "failed to write request to approver")
+	case resp := <-respCh:
+		if resp.Error != nil {
+			return nil, errors.New(resp.Error.Message)
+		}
+		return resp.Result, nil
+	case <-timer.C:
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+		return nil, errors.Errorf("timed out waiting %s for approver response to %s", s.requestTimeout, method)
+	case <-ctx.Done():
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// decodeResult turns a single-call result ("approved", "denied", anything else) into a rules.Result.
+func decodeResult(raw json.RawMessage) rules.Result {
+	var result string
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return rules.FAILED
+	}
+	switch result {
+	case "approved":
+		return rules.APPROVED
+	case "denied":
+		return rules.DENIED
+	default:
+		return rules.FAILED
+	}
+}
+
+// call is the common path for every non-batch rules.Service method: invoke the named RPC method with the
+// given metadata/request pair, denying closed on any transport error.
+func (s *Service) call(ctx context.Context, method string, metadata *rules.ReqMetadata, req interface{}) rules.Result {
+	raw, err := s.invoke(ctx, method, &approvalParams{Metadata: metadata, Req: req})
+	if err != nil {
+		log.Error().Err(err).Str("method", method).Msg("External approver call failed; denying")
+		return rules.FAILED
+	}
+	return decodeResult(raw)
+}
+
+// Close terminates the approver process and releases its resources.
+func (s *Service) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stdin != nil {
+		_ = s.stdin.Close()
+	}
+	if s.cmd != nil && s.cmd.Process != nil {
+		return s.cmd.Process.Kill()
+	}
+
+	return nil
+}
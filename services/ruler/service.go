@@ -0,0 +1,60 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ruler runs an incoming signing request through the configured rules.Service implementation(s) and
+// returns a single combined verdict, so the signer itself never has to talk to rules.Service directly.
+package ruler
+
+import (
+	"context"
+
+	"github.com/attestantio/dirk/rules"
+	"github.com/attestantio/dirk/services/checker"
+)
+
+// Action identifies which operation a RunRules call is checking, so a single dispatcher can route each
+// request to the matching rules.Service method.
+type Action string
+
+// Actions for every signing operation checked via RunRules.  Each signed message type gets its own action,
+// even one that reuses another type's rules.Service data shape, so operators can configure policy
+// independently per message type.
+const (
+	// ActionSignBeaconAttestation is used for phase0 beacon block attestations.
+	ActionSignBeaconAttestation Action = "SIGN_BEACON_ATTESTATION"
+	// ActionSignBeaconAttestationV2 is used for post-Electra (EIP-7549) beacon block attestations, distinct
+	// from ActionSignBeaconAttestation so that committee-bits-aware slashing protection is always applied to
+	// them rather than falling back to the phase0 rule.
+	ActionSignBeaconAttestationV2 Action = "SIGN_BEACON_ATTESTATION_V2"
+	// ActionSignSyncCommitteeMessage is used for Altair sync-committee messages.
+	ActionSignSyncCommitteeMessage Action = "SIGN_SYNC_COMMITTEE_MESSAGE"
+	// ActionSignSyncCommitteeSelection is used for sync-committee aggregator selection proofs.
+	ActionSignSyncCommitteeSelection Action = "SIGN_SYNC_COMMITTEE_SELECTION"
+	// ActionSignContributionAndProof is used for sync-committee ContributionAndProof messages.
+	ActionSignContributionAndProof Action = "SIGN_CONTRIBUTION_AND_PROOF"
+)
+
+// Service runs a signing or access request through the configured rules and returns a single combined
+// verdict.
+type Service interface {
+	// RunRules checks data, for the given action and account, against the configured rules.
+	RunRules(ctx context.Context, credentials *checker.Credentials, action Action, walletName string, accountName string, pubKey []byte, data interface{}) rules.Result
+
+	// RunRulesForBeaconAttestations checks every item of a batch of beacon attestations against the
+	// configured rules in a single call, routing to rules.Service.OnSignBeaconAttestations so that a backing
+	// store such as a slashing protection database can amortize its round-trip across the whole batch rather
+	// than paying it once per attestation.  metadata and data.Data are in lockstep with each other and with
+	// the caller's own batch; an entry the caller has already denied or failed before calling this (a nil
+	// metadata entry) is passed through as denied without being put to the configured rules.
+	RunRulesForBeaconAttestations(ctx context.Context, credentials *checker.Credentials, metadata []*rules.ReqMetadata, data *rules.SignBeaconAttestationsData) []rules.Result
+}
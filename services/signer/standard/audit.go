@@ -0,0 +1,70 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	context "context"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"github.com/attestantio/dirk/services/auditor"
+	"github.com/attestantio/dirk/services/checker"
+)
+
+// recordAudit writes a structured audit record for a single signing decision.  It is called for every sign
+// path after the ruler has reached a verdict and before the result is returned to the caller, so both
+// approvals and denials are captured.  Writing the audit record is best-effort: a backend problem must never
+// fail or block the signing path itself, so failures are only logged.
+func (s *Service) recordAudit(
+	ctx context.Context,
+	credentials *checker.Credentials,
+	action string,
+	accountName string,
+	pubKey []byte,
+	domain []byte,
+	data interface{},
+	rulesResult string,
+	finalResult string,
+	signingRoot []byte,
+) {
+	if s.auditor == nil {
+		return
+	}
+
+	var dataHash [sha256.Size]byte
+	if dataBytes, err := json.Marshal(data); err == nil {
+		dataHash = sha256.Sum256(dataBytes)
+	} else {
+		log.Warn().Err(err).Str("action", action).Msg("Failed to hash audit data")
+	}
+
+	record := &auditor.Record{
+		Timestamp:   time.Now(),
+		RequestID:   credentials.RequestID,
+		Client:      credentials.Client,
+		Account:     accountName,
+		PubKey:      pubKey,
+		Action:      action,
+		Domain:      domain,
+		DataHash:    dataHash[:],
+		RulesResult: rulesResult,
+		FinalResult: finalResult,
+		SigningRoot: signingRoot,
+	}
+
+	if err := s.auditor.Log(ctx, record); err != nil {
+		log.Warn().Err(err).Str("action", action).Msg("Failed to write audit record")
+	}
+}
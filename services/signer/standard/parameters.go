@@ -0,0 +1,92 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"github.com/attestantio/dirk/services/auditor"
+	"github.com/attestantio/dirk/services/monitor"
+	"github.com/attestantio/dirk/services/ruler"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel zerolog.Level
+	ruler    ruler.Service
+	monitor  monitor.Service
+	auditor  auditor.Service
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithRuler sets the ruler used to check requests against the configured rules.
+func WithRuler(ruler ruler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.ruler = ruler
+	})
+}
+
+// WithMonitor sets the monitor to which signing outcomes are reported.
+func WithMonitor(monitor monitor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithAuditor sets the audit sink to which every signing decision is recorded.  It is optional: a service
+// with no auditor configured simply skips recording, since recordAudit's logging is best-effort and must
+// never block or fail the signing path.
+func WithAuditor(auditor auditor.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.auditor = auditor
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and
+// correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.ruler == nil {
+		return nil, errors.New("no ruler specified")
+	}
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,52 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package standard is the default implementation of the signer service: it checks and signs requests
+// directly against local wallets, subject to the configured rules and audit log.
+package standard
+
+import (
+	"github.com/attestantio/dirk/services/auditor"
+	"github.com/attestantio/dirk/services/monitor"
+	"github.com/attestantio/dirk/services/ruler"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// module-wide log.
+var log zerolog.Logger
+
+// Service is the standard signer implementation.
+type Service struct {
+	ruler   ruler.Service
+	monitor monitor.Service
+	auditor auditor.Service
+}
+
+// New creates a new standard signer service.
+func New(params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	log = zerolog.New(zerolog.NewConsoleWriter()).With().Str("service", "signer").Str("impl", "standard").Logger().Level(parameters.logLevel)
+
+	s := &Service{
+		ruler:   parameters.ruler,
+		monitor: parameters.monitor,
+		auditor: parameters.auditor,
+	}
+
+	return s, nil
+}
@@ -115,10 +115,12 @@ func (s *Service) SignBeaconAttestation(
 	case rules.DENIED:
 		log.Debug().Str("result", "denied").Msg("Denied by rules")
 		s.monitor.SignCompleted(started, "attestation", core.ResultDenied)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestation", accountName, pubKey, data.Domain, data, "denied", "denied", nil)
 		return core.ResultDenied, nil
 	case rules.FAILED:
 		log.Error().Str("result", "failed").Msg("Rules check failed")
 		s.monitor.SignCompleted(started, "attestation", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestation", accountName, pubKey, data.Domain, data, "failed", "failed", nil)
 		return core.ResultFailed, nil
 	}
 
@@ -142,16 +144,19 @@ func (s *Service) SignBeaconAttestation(
 	if err != nil {
 		log.Error().Err(err).Str("result", "failed").Msg("Failed to generate signing root")
 		s.monitor.SignCompleted(started, "attestation", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestation", accountName, pubKey, data.Domain, data, "approved", "failed", nil)
 		return core.ResultFailed, nil
 	}
 	signature, err := signRoot(ctx, account, signingRoot[:])
 	if err != nil {
 		log.Error().Err(err).Str("result", "failed").Msg("Failed to sign")
 		s.monitor.SignCompleted(started, "attestation", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestation", accountName, pubKey, data.Domain, data, "approved", "failed", signingRoot[:])
 		return core.ResultFailed, nil
 	}
 
 	log.Trace().Str("result", "succeeded").Msg("Success")
 	s.monitor.SignCompleted(started, "attestation", core.ResultSucceeded)
+	s.recordAudit(ctx, credentials, "SignBeaconAttestation", accountName, pubKey, data.Domain, data, "approved", "succeeded", signingRoot[:])
 	return core.ResultSucceeded, signature
 }
@@ -0,0 +1,218 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	context "context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/dirk/core"
+	"github.com/attestantio/dirk/rules"
+	"github.com/attestantio/dirk/services/checker"
+	"github.com/attestantio/dirk/services/ruler"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// SignBeaconAttestations signs multiple attestations for beacon blocks in a single request.  Each item is
+// validated and checked independently, but the rules for the whole batch are run as a single invocation so
+// that a backing store such as a slashing protection database can amortize its round-trip across the whole
+// batch rather than paying it once per attestation.  A denial or failure of one attestation never affects the
+// others in the batch.
+func (s *Service) SignBeaconAttestations(
+	ctx context.Context,
+	credentials *checker.Credentials,
+	accountNames []string,
+	pubKeys [][]byte,
+	data []*rules.SignBeaconAttestationData,
+) (
+	[]core.Result,
+	[][]byte,
+) {
+	started := time.Now()
+	results := make([]core.Result, len(data))
+	signatures := make([][]byte, len(data))
+	for i := range results {
+		results[i] = core.ResultFailed
+	}
+
+	if credentials == nil {
+		log.Error().Msg("No credentials supplied")
+		return results, signatures
+	}
+
+	log := log.With().
+		Str("request_id", credentials.RequestID).
+		Str("client", credentials.Client).
+		Str("action", "SignBeaconAttestations").
+		Logger()
+	log.Trace().Int("batch_size", len(data)).Msg("Signing batch")
+
+	if len(accountNames) != len(data) || len(pubKeys) != len(data) {
+		log.Warn().Str("result", "denied").Msg("Batch request has mismatched array lengths")
+		for i := range results {
+			results[i] = core.ResultDenied
+			s.monitor.SignCompleted(started, "attestations", core.ResultDenied)
+		}
+		return results, signatures
+	}
+
+	// Validate each item and resolve its account.  An item that fails here is not put to the configured rules;
+	// its slot in the batch rules call below carries a nil metadata entry.
+	accounts := make([]e2wtypes.Account, len(data))
+	resolvedAccountNames := make([]string, len(data))
+	for i := range data {
+		if err := validateBeaconAttestationData(data[i]); err != nil {
+			log.Warn().Str("result", "denied").Err(err).Msg("Batch item failed validation")
+			results[i] = core.ResultDenied
+			s.monitor.SignCompleted(started, "attestations", core.ResultDenied)
+			continue
+		}
+
+		wallet, account, checkRes := s.preCheck(ctx, credentials, accountNames[i], pubKeys[i], ruler.ActionSignBeaconAttestation)
+		if checkRes != core.ResultSucceeded {
+			results[i] = checkRes
+			s.monitor.SignCompleted(started, "attestations", checkRes)
+			continue
+		}
+		accounts[i] = account
+		resolvedAccountNames[i] = fmt.Sprintf("%s/%s", wallet.Name(), account.Name())
+	}
+
+	// Build the batch rules request, leaving a nil metadata entry for any item that was already denied or
+	// failed above so that RunRulesForBeaconAttestations can pass it straight through without consulting the
+	// configured rules for it.
+	ruleMetadata := make([]*rules.ReqMetadata, len(data))
+	rulePubKeys := make([][]byte, len(data))
+	for i := range data {
+		if accounts[i] == nil {
+			continue
+		}
+		rulePubKeys[i] = accounts[i].PublicKey().Marshal()
+		ruleMetadata[i] = &rules.ReqMetadata{
+			Account: resolvedAccountNames[i],
+			PubKey:  rulePubKeys[i],
+			IP:      credentials.IP,
+			Client:  credentials.Client,
+		}
+	}
+	ruleData := &rules.SignBeaconAttestationsData{
+		Accounts: resolvedAccountNames,
+		PubKeys:  rulePubKeys,
+		Data:     data,
+	}
+	ruleResults := s.ruler.RunRulesForBeaconAttestations(ctx, credentials, ruleMetadata, ruleData)
+
+	for i := range data {
+		if accounts[i] == nil {
+			// Already denied or failed during validation/pre-check, above.
+			continue
+		}
+		results[i], signatures[i] = s.signBeaconAttestationItem(ctx, log, credentials, accounts[i], resolvedAccountNames[i], pubKeys[i], data[i], ruleResults[i], started)
+	}
+
+	log.Trace().Str("result", "completed").Msg("Batch complete")
+
+	return results, signatures
+}
+
+// validateBeaconAttestationData confirms that a single item of a SignBeaconAttestations batch carries enough
+// data to be signed, without yet touching wallets or rules.
+func validateBeaconAttestationData(data *rules.SignBeaconAttestationData) error {
+	if data == nil {
+		return errors.New("missing data")
+	}
+	if data.Domain == nil {
+		return errors.New("missing domain")
+	}
+	if data.BeaconBlockRoot == nil {
+		return errors.New("missing beacon block root")
+	}
+	if data.Source == nil || data.Source.Root == nil {
+		return errors.New("missing source")
+	}
+	if data.Target == nil || data.Target.Root == nil {
+		return errors.New("missing target")
+	}
+
+	return nil
+}
+
+// signBeaconAttestationItem signs a single item of a SignBeaconAttestations batch given its already-resolved
+// account and its already-obtained rules result, reporting its own outcome to the monitor and audit log as a
+// standalone SignBeaconAttestation call does, under the monitor label "attestations" and the audit action
+// "SignBeaconAttestations".
+func (s *Service) signBeaconAttestationItem(
+	ctx context.Context,
+	log zerolog.Logger,
+	credentials *checker.Credentials,
+	account e2wtypes.Account,
+	accountName string,
+	pubKey []byte,
+	data *rules.SignBeaconAttestationData,
+	result rules.Result,
+	started time.Time,
+) (core.Result, []byte) {
+	log = log.With().Str("account", accountName).Logger()
+
+	switch result {
+	case rules.DENIED:
+		log.Debug().Str("result", "denied").Msg("Denied by rules")
+		s.monitor.SignCompleted(started, "attestations", core.ResultDenied)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestations", accountName, pubKey, data.Domain, data, "denied", "denied", nil)
+		return core.ResultDenied, nil
+	case rules.FAILED:
+		log.Error().Str("result", "failed").Msg("Rules check failed")
+		s.monitor.SignCompleted(started, "attestations", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestations", accountName, pubKey, data.Domain, data, "failed", "failed", nil)
+		return core.ResultFailed, nil
+	}
+
+	// Create a local copy of the data; we need ssz size information to calculate the correct root.
+	attestation := &BeaconAttestation{
+		Slot:            data.Slot,
+		CommitteeIndex:  data.CommitteeIndex,
+		BeaconBlockRoot: data.BeaconBlockRoot,
+		Source: &Checkpoint{
+			Epoch: data.Source.Epoch,
+			Root:  data.Source.Root,
+		},
+		Target: &Checkpoint{
+			Epoch: data.Target.Epoch,
+			Root:  data.Target.Root,
+		},
+	}
+
+	signingRoot, err := generateSigningRootFromData(ctx, attestation, data.Domain)
+	if err != nil {
+		log.Error().Err(err).Str("result", "failed").Msg("Failed to generate signing root")
+		s.monitor.SignCompleted(started, "attestations", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestations", accountName, pubKey, data.Domain, data, "approved", "failed", nil)
+		return core.ResultFailed, nil
+	}
+	signature, err := signRoot(ctx, account, signingRoot[:])
+	if err != nil {
+		log.Error().Err(err).Str("result", "failed").Msg("Failed to sign")
+		s.monitor.SignCompleted(started, "attestations", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestations", accountName, pubKey, data.Domain, data, "approved", "failed", signingRoot[:])
+		return core.ResultFailed, nil
+	}
+
+	log.Trace().Str("result", "succeeded").Msg("Success")
+	s.monitor.SignCompleted(started, "attestations", core.ResultSucceeded)
+	s.recordAudit(ctx, credentials, "SignBeaconAttestations", accountName, pubKey, data.Domain, data, "approved", "succeeded", signingRoot[:])
+	return core.ResultSucceeded, signature
+}
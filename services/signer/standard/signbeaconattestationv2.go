@@ -0,0 +1,163 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	context "context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/dirk/core"
+	"github.com/attestantio/dirk/rules"
+	"github.com/attestantio/dirk/services/checker"
+	"github.com/attestantio/dirk/services/ruler"
+)
+
+// BeaconAttestationV2 is a copy of the Ethereum 2 Electra (EIP-7549) AttestationData structure with SSZ size
+// information.  Unlike the phase0 layout the committee index is carried out-of-band in the surrounding
+// Attestation's CommitteeBits, so Index is always zero here.
+type BeaconAttestationV2 struct {
+	Slot            uint64
+	Index           uint64
+	BeaconBlockRoot []byte `ssz-size:"32"`
+	Source          *Checkpoint
+	Target          *Checkpoint
+}
+
+// SignBeaconAttestationV2 signs a post-Electra (EIP-7549) attestation for a beacon block.
+func (s *Service) SignBeaconAttestationV2(
+	ctx context.Context,
+	credentials *checker.Credentials,
+	accountName string,
+	pubKey []byte,
+	data *rules.SignBeaconAttestationV2Data,
+) (
+	core.Result,
+	[]byte,
+) {
+	started := time.Now()
+
+	if credentials == nil {
+		log.Error().Msg("No credentials supplied")
+		return core.ResultFailed, nil
+	}
+
+	log := log.With().
+		Str("request_id", credentials.RequestID).
+		Str("client", credentials.Client).
+		Str("action", "SignBeaconAttestationV2").
+		Logger()
+	log.Trace().Msg("Signing")
+
+	// Check input.
+	if data == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing data")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if data.BeaconBlockRoot == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing beacon block root")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if data.Domain == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing domain")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if data.Source == nil || data.Source.Root == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing source")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if data.Target == nil || data.Target.Root == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing target")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if len(data.CommitteeBits) != rules.MaxCommitteesPerSlot/8 {
+		log.Warn().Str("result", "denied").Msg("Request has malformed committee bits")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if data.AggregationBits == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing aggregation bits")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+
+	wallet, account, checkRes := s.preCheck(ctx, credentials, accountName, pubKey, ruler.ActionSignBeaconAttestationV2)
+	if checkRes != core.ResultSucceeded {
+		s.monitor.SignCompleted(started, "attestationv2", checkRes)
+		return checkRes, nil
+	}
+	accountName = fmt.Sprintf("%s/%s", wallet.Name(), account.Name())
+	log = log.With().Str("account", accountName).Logger()
+
+	// Confirm approval via rules.  This is its own action, distinct from phase0's ActionSignBeaconAttestation,
+	// so that it reaches rules.Service.OnSignBeaconAttestationV2 - the rules implementation is responsible for
+	// expanding CommitteeBits into the set of committees covered and evaluating slashing protection as if this
+	// were one logical attestation per covered committee.
+	result := s.ruler.RunRules(ctx, credentials, ruler.ActionSignBeaconAttestationV2, wallet.Name(), account.Name(), account.PublicKey().Marshal(), data)
+	switch result {
+	case rules.DENIED:
+		log.Debug().Str("result", "denied").Msg("Denied by rules")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultDenied)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestationV2", accountName, pubKey, data.Domain, data, "denied", "denied", nil)
+		return core.ResultDenied, nil
+	case rules.FAILED:
+		log.Error().Str("result", "failed").Msg("Rules check failed")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestationV2", accountName, pubKey, data.Domain, data, "failed", "failed", nil)
+		return core.ResultFailed, nil
+	}
+
+	// Create a local copy of the data; we need ssz size information to calculate the correct root.  The
+	// Electra AttestationData always carries an index of zero; the covered committees live in CommitteeBits
+	// on the surrounding Attestation, not in this signing root.
+	attestation := &BeaconAttestationV2{
+		Slot:            data.Slot,
+		Index:           0,
+		BeaconBlockRoot: data.BeaconBlockRoot,
+		Source: &Checkpoint{
+			Epoch: data.Source.Epoch,
+			Root:  data.Source.Root,
+		},
+		Target: &Checkpoint{
+			Epoch: data.Target.Epoch,
+			Root:  data.Target.Root,
+		},
+	}
+
+	// Sign it.
+	signingRoot, err := generateSigningRootFromData(ctx, attestation, data.Domain)
+	if err != nil {
+		log.Error().Err(err).Str("result", "failed").Msg("Failed to generate signing root")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestationV2", accountName, pubKey, data.Domain, data, "approved", "failed", nil)
+		return core.ResultFailed, nil
+	}
+	signature, err := signRoot(ctx, account, signingRoot[:])
+	if err != nil {
+		log.Error().Err(err).Str("result", "failed").Msg("Failed to sign")
+		s.monitor.SignCompleted(started, "attestationv2", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignBeaconAttestationV2", accountName, pubKey, data.Domain, data, "approved", "failed", signingRoot[:])
+		return core.ResultFailed, nil
+	}
+
+	log.Trace().Str("result", "succeeded").Msg("Success")
+	s.monitor.SignCompleted(started, "attestationv2", core.ResultSucceeded)
+	s.recordAudit(ctx, credentials, "SignBeaconAttestationV2", accountName, pubKey, data.Domain, data, "approved", "succeeded", signingRoot[:])
+	return core.ResultSucceeded, signature
+}
@@ -0,0 +1,145 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	context "context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/dirk/core"
+	"github.com/attestantio/dirk/rules"
+	"github.com/attestantio/dirk/services/checker"
+	"github.com/attestantio/dirk/services/ruler"
+)
+
+// SyncCommitteeContribution is a copy of the Ethereum 2 SyncCommitteeContribution structure with SSZ size
+// information.
+type SyncCommitteeContribution struct {
+	Slot              uint64
+	BeaconBlockRoot   []byte `ssz-size:"32"`
+	SubcommitteeIndex uint64
+	AggregationBits   []byte `ssz-size:"16"`
+	Signature         []byte `ssz-size:"96"`
+}
+
+// ContributionAndProof is a copy of the Ethereum 2 ContributionAndProof structure with SSZ size information.
+type ContributionAndProof struct {
+	AggregatorIndex uint64
+	Contribution    *SyncCommitteeContribution
+	SelectionProof  []byte `ssz-size:"96"`
+}
+
+// SignContributionAndProof signs a sync-committee ContributionAndProof.
+func (s *Service) SignContributionAndProof(
+	ctx context.Context,
+	credentials *checker.Credentials,
+	accountName string,
+	pubKey []byte,
+	data *rules.SignContributionAndProofData,
+) (
+	core.Result,
+	[]byte,
+) {
+	started := time.Now()
+
+	if credentials == nil {
+		log.Error().Msg("No credentials supplied")
+		return core.ResultFailed, nil
+	}
+
+	log := log.With().
+		Str("request_id", credentials.RequestID).
+		Str("client", credentials.Client).
+		Str("action", "SignContributionAndProof").
+		Logger()
+	log.Trace().Msg("Signing")
+
+	// Check input.
+	if data == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing data")
+		s.monitor.SignCompleted(started, "contributionandproof", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if data.Domain == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing domain")
+		s.monitor.SignCompleted(started, "contributionandproof", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if data.ContributionBeaconBlockRoot == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing contribution beacon block root")
+		s.monitor.SignCompleted(started, "contributionandproof", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if data.SelectionProof == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing selection proof")
+		s.monitor.SignCompleted(started, "contributionandproof", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+
+	wallet, account, checkRes := s.preCheck(ctx, credentials, accountName, pubKey, ruler.ActionSignContributionAndProof)
+	if checkRes != core.ResultSucceeded {
+		s.monitor.SignCompleted(started, "contributionandproof", checkRes)
+		return checkRes, nil
+	}
+	accountName = fmt.Sprintf("%s/%s", wallet.Name(), account.Name())
+	log = log.With().Str("account", accountName).Logger()
+
+	// Confirm approval via rules.
+	result := s.ruler.RunRules(ctx, credentials, ruler.ActionSignContributionAndProof, wallet.Name(), account.Name(), account.PublicKey().Marshal(), data)
+	switch result {
+	case rules.DENIED:
+		log.Debug().Str("result", "denied").Msg("Denied by rules")
+		s.monitor.SignCompleted(started, "contributionandproof", core.ResultDenied)
+		s.recordAudit(ctx, credentials, "SignContributionAndProof", accountName, pubKey, data.Domain, data, "denied", "denied", nil)
+		return core.ResultDenied, nil
+	case rules.FAILED:
+		log.Error().Str("result", "failed").Msg("Rules check failed")
+		s.monitor.SignCompleted(started, "contributionandproof", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignContributionAndProof", accountName, pubKey, data.Domain, data, "failed", "failed", nil)
+		return core.ResultFailed, nil
+	}
+
+	contributionAndProof := &ContributionAndProof{
+		AggregatorIndex: data.AggregatorIndex,
+		Contribution: &SyncCommitteeContribution{
+			Slot:              data.ContributionSlot,
+			BeaconBlockRoot:   data.ContributionBeaconBlockRoot,
+			SubcommitteeIndex: data.ContributionSubcommitteeIndex,
+			AggregationBits:   data.ContributionAggregationBits,
+			Signature:         data.ContributionSignature,
+		},
+		SelectionProof: data.SelectionProof,
+	}
+
+	signingRoot, err := generateSigningRootFromData(ctx, contributionAndProof, data.Domain)
+	if err != nil {
+		log.Error().Err(err).Str("result", "failed").Msg("Failed to generate signing root")
+		s.monitor.SignCompleted(started, "contributionandproof", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignContributionAndProof", accountName, pubKey, data.Domain, data, "approved", "failed", nil)
+		return core.ResultFailed, nil
+	}
+	signature, err := signRoot(ctx, account, signingRoot[:])
+	if err != nil {
+		log.Error().Err(err).Str("result", "failed").Msg("Failed to sign")
+		s.monitor.SignCompleted(started, "contributionandproof", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignContributionAndProof", accountName, pubKey, data.Domain, data, "approved", "failed", signingRoot[:])
+		return core.ResultFailed, nil
+	}
+
+	log.Trace().Str("result", "succeeded").Msg("Success")
+	s.monitor.SignCompleted(started, "contributionandproof", core.ResultSucceeded)
+	s.recordAudit(ctx, credentials, "SignContributionAndProof", accountName, pubKey, data.Domain, data, "approved", "succeeded", signingRoot[:])
+	return core.ResultSucceeded, signature
+}
@@ -0,0 +1,119 @@
+// Copyright © 2020 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	context "context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/dirk/core"
+	"github.com/attestantio/dirk/rules"
+	"github.com/attestantio/dirk/services/checker"
+	"github.com/attestantio/dirk/services/ruler"
+)
+
+// SyncAggregatorSelectionData is a copy of the Ethereum 2 SyncAggregatorSelectionData structure, signed by a
+// sync-committee member to prove it is the aggregator for a subcommittee.  Both fields are fixed-size, so it
+// needs no SSZ size tags.
+type SyncAggregatorSelectionData struct {
+	Slot              uint64
+	SubcommitteeIndex uint64
+}
+
+// SignSyncCommitteeSelection signs a sync-committee aggregator selection proof.
+func (s *Service) SignSyncCommitteeSelection(
+	ctx context.Context,
+	credentials *checker.Credentials,
+	accountName string,
+	pubKey []byte,
+	data *rules.SignSyncCommitteeSelectionData,
+) (
+	core.Result,
+	[]byte,
+) {
+	started := time.Now()
+
+	if credentials == nil {
+		log.Error().Msg("No credentials supplied")
+		return core.ResultFailed, nil
+	}
+
+	log := log.With().
+		Str("request_id", credentials.RequestID).
+		Str("client", credentials.Client).
+		Str("action", "SignSyncCommitteeSelection").
+		Logger()
+	log.Trace().Msg("Signing")
+
+	// Check input.
+	if data == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing data")
+		s.monitor.SignCompleted(started, "synccommitteeselection", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+	if data.Domain == nil {
+		log.Warn().Str("result", "denied").Msg("Request missing domain")
+		s.monitor.SignCompleted(started, "synccommitteeselection", core.ResultDenied)
+		return core.ResultDenied, nil
+	}
+
+	wallet, account, checkRes := s.preCheck(ctx, credentials, accountName, pubKey, ruler.ActionSignSyncCommitteeSelection)
+	if checkRes != core.ResultSucceeded {
+		s.monitor.SignCompleted(started, "synccommitteeselection", checkRes)
+		return checkRes, nil
+	}
+	accountName = fmt.Sprintf("%s/%s", wallet.Name(), account.Name())
+	log = log.With().Str("account", accountName).Logger()
+
+	// Confirm approval via rules.
+	result := s.ruler.RunRules(ctx, credentials, ruler.ActionSignSyncCommitteeSelection, wallet.Name(), account.Name(), account.PublicKey().Marshal(), data)
+	switch result {
+	case rules.DENIED:
+		log.Debug().Str("result", "denied").Msg("Denied by rules")
+		s.monitor.SignCompleted(started, "synccommitteeselection", core.ResultDenied)
+		s.recordAudit(ctx, credentials, "SignSyncCommitteeSelection", accountName, pubKey, data.Domain, data, "denied", "denied", nil)
+		return core.ResultDenied, nil
+	case rules.FAILED:
+		log.Error().Str("result", "failed").Msg("Rules check failed")
+		s.monitor.SignCompleted(started, "synccommitteeselection", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignSyncCommitteeSelection", accountName, pubKey, data.Domain, data, "failed", "failed", nil)
+		return core.ResultFailed, nil
+	}
+
+	selectionData := &SyncAggregatorSelectionData{
+		Slot:              data.Slot,
+		SubcommitteeIndex: data.SubcommitteeIndex,
+	}
+
+	signingRoot, err := generateSigningRootFromData(ctx, selectionData, data.Domain)
+	if err != nil {
+		log.Error().Err(err).Str("result", "failed").Msg("Failed to generate signing root")
+		s.monitor.SignCompleted(started, "synccommitteeselection", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignSyncCommitteeSelection", accountName, pubKey, data.Domain, data, "approved", "failed", nil)
+		return core.ResultFailed, nil
+	}
+	signature, err := signRoot(ctx, account, signingRoot[:])
+	if err != nil {
+		log.Error().Err(err).Str("result", "failed").Msg("Failed to sign")
+		s.monitor.SignCompleted(started, "synccommitteeselection", core.ResultFailed)
+		s.recordAudit(ctx, credentials, "SignSyncCommitteeSelection", accountName, pubKey, data.Domain, data, "approved", "failed", signingRoot[:])
+		return core.ResultFailed, nil
+	}
+
+	log.Trace().Str("result", "succeeded").Msg("Success")
+	s.monitor.SignCompleted(started, "synccommitteeselection", core.ResultSucceeded)
+	s.recordAudit(ctx, credentials, "SignSyncCommitteeSelection", accountName, pubKey, data.Domain, data, "approved", "succeeded", signingRoot[:])
+	return core.ResultSucceeded, signature
+}